@@ -17,8 +17,8 @@ var (
 
 // Decode nonces to bytes for direct use
 var (
-	InitialNonceBytes = []byte{65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65,65}
-	SessionNonceBytes = []byte{66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66,66}
+	InitialNonceBytes = []byte{65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65}
+	SessionNonceBytes = []byte{66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66}
 )
 
 // Protocol parameters for BRC-104 authentication
@@ -36,30 +36,95 @@ const TestCounterpartyKey = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d95
 // Expected signature data (what should be signed)
 // For signing: initial_nonce_bytes + session_nonce_bytes
 var ExpectedSigDataSigning = []byte{
-	65,65,65,65,65,65,65,65,
-	65,65,65,65,65,65,65,65,
-	65,65,65,65,65,65,65,65,
-	65,65,65,65,65,65,65,65,
-	66,66,66,66,66,66,66,66,
-	66,66,66,66,66,66,66,66,
-	66,66,66,66,66,66,66,66,
-	66,66,66,66,66,66,66,66,
+	65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65,
+	66, 66, 66, 66, 66, 66, 66, 66,
+	66, 66, 66, 66, 66, 66, 66, 66,
+	66, 66, 66, 66, 66, 66, 66, 66,
+	66, 66, 66, 66, 66, 66, 66, 66,
 }
 
 // For verification: session_nonce_bytes + initial_nonce_bytes
 var ExpectedSigDataVerification = []byte{
-	66,66,66,66,66,66,66,66,
-	66,66,66,66,66,66,66,66,
-	66,66,66,66,66,66,66,66,
-	66,66,66,66,66,66,66,66,
-	65,65,65,65,65,65,65,65,
-	65,65,65,65,65,65,65,65,
-	65,65,65,65,65,65,65,65,
-	65,65,65,65,65,65,65,65,
+	66, 66, 66, 66, 66, 66, 66, 66,
+	66, 66, 66, 66, 66, 66, 66, 66,
+	66, 66, 66, 66, 66, 66, 66, 66,
+	66, 66, 66, 66, 66, 66, 66, 66,
+	65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65,
 }
 
 // Test message for general message signing
 var TestMessage = []byte("Hello, this is a test message for BRC-104 authentication")
 
 // Timeout for operations (in seconds)
-const TestTimeout = 10
\ No newline at end of file
+const TestTimeout = 10
+
+// BIP-39 mnemonic fixture for deriving a BRC-104 identity key.
+//
+// These values are shared across Python/TS/Go so all three implementations
+// can assert they derive the same identity key via mnemonic.FromMnemonic.
+var (
+	// TestMnemonic is the canonical all-zero BIP-39 test vector ("Trezor"
+	// vector), not a repo-specific phrase: it's valid BIP-39 English-wordlist
+	// text with a correct checksum, so Python (mnemonic/bip_utils) and TS
+	// (bip39) drivers can load it without bypassing their own validation,
+	// which a made-up phrase would require.
+	TestMnemonic           = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	TestMnemonicPassphrase = ""
+
+	// ExpectedMnemonicPrivateKeyWIF is the WIF encoding of the private key
+	// derived from TestMnemonic/TestMnemonicPassphrase at mnemonic.DerivationPath.
+	ExpectedMnemonicPrivateKeyWIF = "KxU83MzcLXP1WJtoFJXMDMcN3z5ykAa9xLdFTDY5XpV4e6Zit9BA"
+)
+
+// Argon2id session-stretching fixture, shared across Python/TS/Go so all
+// three implementations can assert they stretch the same nonces/counterparty
+// into the same session key.
+//
+// ExpectedStretchedSigData is sessionkdf.BindSignatureData(
+// ExpectedSigDataSigning, stretched), where stretched is
+// NewArgon2idKDF().Stretch(InitialNonceBytes, SessionNonceBytes,
+// counterpartyPub) and counterpartyPub is TestCounterpartyKey parsed and
+// re-serialized compressed (the same 33 bytes TestCounterpartyKey already
+// decodes to).
+var ExpectedStretchedSigData = []byte{
+	0xe9, 0xa1, 0x5b, 0x41, 0xec, 0x1b, 0x9b, 0xca,
+	0xa8, 0x7f, 0x44, 0xcf, 0xed, 0xdc, 0x4d, 0x6a,
+	0xb3, 0xb5, 0x50, 0x06, 0xb9, 0x4c, 0x00, 0x1a,
+	0xfe, 0x93, 0x99, 0xc1, 0xa2, 0x5c, 0x72, 0x81,
+}
+
+// ProtocolDescriptor v2 fixtures, shared across Python/TS/Go so all three
+// implementations agree on the canonical CBOR bytes and the resulting
+// MakeKeyIdV2 output for DefaultProtocolDescriptor.
+var (
+	// ExpectedProtocolDescriptorCBORHex is DefaultProtocolDescriptor.CBOR()
+	// hex-encoded.
+	ExpectedProtocolDescriptorCBORHex = "a4646e616d657661757468206d657373616765207369676e61747572656776657273696f6e016a657874656e73696f6e73a06e73656375726974795f6c6576656c02"
+
+	// ExpectedKeyIdV2 is MakeKeyIdV2(DefaultProtocolDescriptor,
+	// InitialNonceBytes, SessionNonceBytes).
+	ExpectedKeyIdV2 = "AAAAQqRkbmFtZXZhdXRoIG1lc3NhZ2Ugc2lnbmF0dXJlZ3ZlcnNpb24BamV4dGVuc2lvbnOgbnNlY3VyaXR5X2xldmVsAgAAACBBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQQAAACBCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQg=="
+)
+
+// Ed25519 signature suite fixture, shared across Python/TS/Go so all three
+// implementations agree on the identity public key and signature produced
+// for ExpectedSigDataSigning by the Ed25519 suite.
+var (
+	// TestEd25519PrivateKeyHex is a 32-byte Ed25519 seed (fixed for testing).
+	TestEd25519PrivateKeyHex = "4545454545454545454545454545454545454545454545454545454545454545"
+
+	// ExpectedEd25519PublicKeyHex is the public key derived from
+	// TestEd25519PrivateKeyHex.
+	ExpectedEd25519PublicKeyHex = "6355691c178a8ff91007a7478afb955ef7352c63e7b25703984cf78b26e21a56"
+
+	// ExpectedEd25519Signature is Ed25519's signature over
+	// ExpectedSigDataSigning using TestEd25519PrivateKeyHex, without the
+	// SigSuite ID byte prepended.
+	ExpectedEd25519Signature = "fa05a9e65631c319a53a93800811b7c4968dd0eab66de602261f1541c90929fe3815d7f4f65898b048584e411b8b3aa8bcb07c7b93564a953b3c62cba6cf8f0f"
+)
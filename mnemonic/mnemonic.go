@@ -0,0 +1,97 @@
+// Package mnemonic derives BRC-104 identity keys from BIP-39 mnemonic
+// phrases, so HD-managed identities can be loaded into auth handshakes
+// instead of raw WIFs.
+package mnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// DerivationPath is the BRC-104-appropriate HD path used to derive an
+// identity key from a BIP-39 seed.
+const DerivationPath = "m/44'/236'/0'/0/0"
+
+const hardenedOffset = 0x80000000
+
+// derivationIndices is DerivationPath pre-parsed into BIP-32 child indices.
+var derivationIndices = []uint32{
+	44 + hardenedOffset,
+	236 + hardenedOffset,
+	0 + hardenedOffset,
+	0,
+	0,
+}
+
+// curveOrder is the order (n) of the secp256k1 curve.
+var curveOrder, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+// FromMnemonic derives the secp256k1 private key at DerivationPath from a
+// BIP-39 mnemonic and optional passphrase.
+//
+// The seed is computed as PBKDF2-HMAC-SHA512(words, "mnemonic"+passphrase,
+// 2048 iterations, 64-byte output) per BIP-39, and the identity key is then
+// obtained via standard BIP-32 private-key derivation. The mnemonic's BIP-39
+// checksum is not validated here; callers are expected to validate word
+// list membership and checksum bits before calling FromMnemonic.
+func FromMnemonic(words, passphrase string) (*ec.PrivateKey, error) {
+	seed := pbkdf2.Key([]byte(words), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+
+	key, chainCode := masterKeyFromSeed(seed)
+
+	var err error
+	for _, index := range derivationIndices {
+		key, chainCode, err = deriveChild(key, chainCode, index)
+		if err != nil {
+			return nil, fmt.Errorf("mnemonic: deriving child at index %d: %w", index, err)
+		}
+	}
+
+	return ec.PrivateKeyFromBytes(key)
+}
+
+// masterKeyFromSeed implements the BIP-32 master key generation function.
+func masterKeyFromSeed(seed []byte) (key, chainCode []byte) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// deriveChild implements BIP-32 CKDpriv for both hardened and normal indices.
+func deriveChild(parentKey, parentChainCode []byte, index uint32) (childKey, childChainCode []byte, err error) {
+	var data []byte
+	if index&hardenedOffset != 0 {
+		data = append([]byte{0x00}, parentKey...)
+	} else {
+		parentPriv, err := ec.PrivateKeyFromBytes(parentKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing parent key: %w", err)
+		}
+		data = parentPriv.PubKey().SerializeCompressed()
+	}
+
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	data = append(data, indexBytes...)
+
+	mac := hmac.New(sha512.New, parentChainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	il := new(big.Int).SetBytes(sum[:32])
+	parent := new(big.Int).SetBytes(parentKey)
+	child := new(big.Int).Add(il, parent)
+	child.Mod(child, curveOrder)
+
+	childKeyBytes := make([]byte, 32)
+	child.FillBytes(childKeyBytes)
+
+	return childKeyBytes, sum[32:], nil
+}
@@ -0,0 +1,288 @@
+// Package noncecache provides bounded-memory nonce-replay detection for
+// BRC-104 handshakes. A cuckoo filter gives O(1) amortized membership
+// checks without storing every nonce ever seen, backed by a small LRU of
+// exact recent nonces to disambiguate the filter's false positives and to
+// track timestamps for windowed expiry.
+package noncecache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	bucketSize       = 4
+	maxDisplacements = 500
+	defaultBuckets   = 1 << 12 // 4096 buckets * 4 slots = 16384 fingerprints, ~0.9 load factor at defaultLRUSize
+	defaultLRUSize   = 14745   // 16384 * 0.9
+	defaultWindow    = 5 * time.Minute
+)
+
+// NonceCache detects nonce replay within a bounded memory budget.
+type NonceCache interface {
+	// SeenOrRecord reports whether nonce was already recorded within the
+	// cache's retention window and, if not, records it at ts.
+	SeenOrRecord(nonce [32]byte, ts time.Time) bool
+	// Prune discards entries recorded before the given time and returns how
+	// many entries were discarded.
+	Prune(before time.Time) int
+}
+
+type lruEntry struct {
+	nonce      [32]byte
+	ts         time.Time
+	prev, next *lruEntry
+}
+
+// CuckooNonceCache is the default NonceCache implementation: a cuckoo
+// filter (16-bit fingerprints, 4 entries/bucket) for membership checks,
+// plus an LRU of exact nonces for false-positive disambiguation and
+// window-based expiry.
+type CuckooNonceCache struct {
+	mu         sync.Mutex
+	buckets    [][bucketSize]uint16
+	numBuckets uint32
+
+	lruIndex         map[[32]byte]*lruEntry
+	lruHead, lruTail *lruEntry
+	lruLen, lruCap   int
+
+	window time.Duration
+}
+
+// New returns a CuckooNonceCache that rejects a nonce reused within window
+// of its first sighting. A window <= 0 uses the default 5-minute window.
+func New(window time.Duration) *CuckooNonceCache {
+	if window <= 0 {
+		window = defaultWindow
+	}
+	return &CuckooNonceCache{
+		buckets:    make([][bucketSize]uint16, defaultBuckets),
+		numBuckets: defaultBuckets,
+		lruIndex:   make(map[[32]byte]*lruEntry, defaultLRUSize),
+		lruCap:     defaultLRUSize,
+		window:     window,
+	}
+}
+
+// SeenOrRecord implements NonceCache.
+func (c *CuckooNonceCache) SeenOrRecord(nonce [32]byte, ts time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.containsFingerprint(nonce) {
+		if entry, ok := c.lruIndex[nonce]; ok {
+			if ts.Sub(entry.ts) <= c.window {
+				c.touchLRU(entry)
+				return true
+			}
+			// Outside the window: treat as a fresh presentation.
+			entry.ts = ts
+			c.touchLRU(entry)
+			return false
+		}
+		// Cuckoo filter false positive: the fingerprint collided but this
+		// exact nonce was never recorded, so fall through and record it.
+	}
+
+	c.record(nonce, ts)
+	return false
+}
+
+// Prune discards LRU entries recorded before `before`, removing their
+// fingerprints from the cuckoo filter too, and returns how many were
+// discarded. It walks every live entry (O(live entries)), since a touched
+// entry's position in the list reflects last access rather than ts.
+func (c *CuckooNonceCache) Prune(before time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for entry := c.lruTail; entry != nil; {
+		prev := entry.prev
+		if entry.ts.Before(before) {
+			c.removeLRU(entry)
+			removed++
+		}
+		entry = prev
+	}
+	return removed
+}
+
+func (c *CuckooNonceCache) record(nonce [32]byte, ts time.Time) {
+	fp, i1 := fingerprintAndBucket(nonce, c.numBuckets)
+	i2 := altIndex(i1, fp, c.numBuckets)
+
+	if !c.insertFingerprint(fp, i1, i2) {
+		c.rebuild()
+		_, i1 = fingerprintAndBucket(nonce, c.numBuckets)
+		i2 = altIndex(i1, fp, c.numBuckets)
+		// If the filter is still full immediately after doubling, the
+		// nonce is tracked via the LRU only; it will simply miss the
+		// fast-path membership check on a future replay.
+		c.insertFingerprint(fp, i1, i2)
+	}
+
+	entry := &lruEntry{nonce: nonce, ts: ts}
+	c.lruIndex[nonce] = entry
+	c.pushFrontLRU(entry)
+
+	if c.lruLen > c.lruCap {
+		c.removeLRU(c.lruTail)
+	}
+}
+
+// insertFingerprint places fp into bucket i1 or i2, cuckoo-evicting and
+// relocating existing entries (capped at maxDisplacements) if both are
+// full. It reports whether fp was placed.
+func (c *CuckooNonceCache) insertFingerprint(fp uint16, i1, i2 uint32) bool {
+	if c.insertIntoBucket(i1, fp) {
+		return true
+	}
+	if c.insertIntoBucket(i2, fp) {
+		return true
+	}
+
+	i := i1
+	for attempt := 0; attempt < maxDisplacements; attempt++ {
+		slot := rand.Intn(bucketSize)
+		evicted := c.buckets[i][slot]
+		c.buckets[i][slot] = fp
+
+		fp = evicted
+		i = altIndex(i, fp, c.numBuckets)
+		if c.insertIntoBucket(i, fp) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CuckooNonceCache) insertIntoBucket(idx uint32, fp uint16) bool {
+	bucket := &c.buckets[idx]
+	for slot := 0; slot < bucketSize; slot++ {
+		if bucket[slot] == 0 {
+			bucket[slot] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// deleteFingerprint removes nonce's fingerprint from whichever of its two
+// buckets holds it, so capacity-evicted and pruned entries don't leave the
+// filter's occupied-slot count growing without bound.
+func (c *CuckooNonceCache) deleteFingerprint(nonce [32]byte) {
+	fp, i1 := fingerprintAndBucket(nonce, c.numBuckets)
+	i2 := altIndex(i1, fp, c.numBuckets)
+	if removeFromBucket(&c.buckets[i1], fp) {
+		return
+	}
+	removeFromBucket(&c.buckets[i2], fp)
+}
+
+func (c *CuckooNonceCache) containsFingerprint(nonce [32]byte) bool {
+	fp, i1 := fingerprintAndBucket(nonce, c.numBuckets)
+	i2 := altIndex(i1, fp, c.numBuckets)
+	return bucketHas(c.buckets[i1], fp) || bucketHas(c.buckets[i2], fp)
+}
+
+// rebuild doubles the filter's capacity and reinserts the fingerprint of
+// every nonce still tracked in the LRU, which is the cache's ground truth.
+func (c *CuckooNonceCache) rebuild() {
+	c.numBuckets *= 2
+	c.buckets = make([][bucketSize]uint16, c.numBuckets)
+
+	for entry := c.lruHead; entry != nil; entry = entry.next {
+		fp, i1 := fingerprintAndBucket(entry.nonce, c.numBuckets)
+		i2 := altIndex(i1, fp, c.numBuckets)
+		c.insertFingerprint(fp, i1, i2)
+	}
+}
+
+func bucketHas(bucket [bucketSize]uint16, fp uint16) bool {
+	for _, v := range bucket {
+		if v == fp {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFromBucket(bucket *[bucketSize]uint16, fp uint16) bool {
+	for slot := range bucket {
+		if bucket[slot] == fp {
+			bucket[slot] = 0
+			return true
+		}
+	}
+	return false
+}
+
+// fingerprintAndBucket hashes nonce once and derives both its fingerprint
+// (0 is reserved to mean "empty slot") and its primary bucket index from
+// the single digest.
+func fingerprintAndBucket(nonce [32]byte, numBuckets uint32) (fp uint16, bucket uint32) {
+	sum := sha256.Sum256(nonce[:])
+	fp = binary.BigEndian.Uint16(sum[4:6])
+	if fp == 0 {
+		fp = 1
+	}
+	bucket = binary.BigEndian.Uint32(sum[:4]) % numBuckets
+	return fp, bucket
+}
+
+// altIndex computes a fingerprint's other bucket given one of its two
+// bucket indices: partial-key cuckoo hashing's XOR trick makes this
+// involution its own inverse, i.e. altIndex(altIndex(i, fp), fp) == i.
+func altIndex(i uint32, fp uint16, numBuckets uint32) uint32 {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], fp)
+	sum := sha256.Sum256(b[:])
+	return (i ^ binary.BigEndian.Uint32(sum[:4])) % numBuckets
+}
+
+func (c *CuckooNonceCache) pushFrontLRU(entry *lruEntry) {
+	entry.prev = nil
+	entry.next = c.lruHead
+	if c.lruHead != nil {
+		c.lruHead.prev = entry
+	}
+	c.lruHead = entry
+	if c.lruTail == nil {
+		c.lruTail = entry
+	}
+	c.lruLen++
+}
+
+func (c *CuckooNonceCache) touchLRU(entry *lruEntry) {
+	if c.lruHead == entry {
+		return
+	}
+	c.unlinkLRU(entry)
+	c.pushFrontLRU(entry)
+}
+
+func (c *CuckooNonceCache) unlinkLRU(entry *lruEntry) {
+	if entry.prev != nil {
+		entry.prev.next = entry.next
+	} else {
+		c.lruHead = entry.next
+	}
+	if entry.next != nil {
+		entry.next.prev = entry.prev
+	} else {
+		c.lruTail = entry.prev
+	}
+	entry.prev, entry.next = nil, nil
+	c.lruLen--
+}
+
+func (c *CuckooNonceCache) removeLRU(entry *lruEntry) {
+	c.unlinkLRU(entry)
+	delete(c.lruIndex, entry.nonce)
+	c.deleteFingerprint(entry.nonce)
+}
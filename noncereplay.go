@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"cross-language-tests/noncecache"
+)
+
+// ErrNonceReplay is returned when a nonce has already been seen within the
+// cache's retention window.
+var ErrNonceReplay = errors.New("brc104: nonce replay detected")
+
+// handleInitialRequest models the replay check a real BRC-104
+// initial-response handler performs before accepting an initial nonce:
+// reject any base64 nonce already recorded in cache within its window.
+func handleInitialRequest(cache noncecache.NonceCache, nonceB64 string, ts time.Time) error {
+	decoded, err := base64.StdEncoding.DecodeString(nonceB64)
+	if err != nil {
+		return fmt.Errorf("brc104: decoding nonce: %w", err)
+	}
+	if len(decoded) != 32 {
+		return fmt.Errorf("brc104: nonce must be 32 bytes, got %d", len(decoded))
+	}
+
+	var nonce [32]byte
+	copy(nonce[:], decoded)
+
+	if cache.SeenOrRecord(nonce, ts) {
+		return ErrNonceReplay
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"sort"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// ProtocolDescriptor is a typed, versioned replacement for the old
+// []interface{} ProtocolID. Unlike ProtocolID, it has a canonical CBOR
+// encoding, so its serialization order and version are unambiguous on the
+// wire rather than implied by argument position.
+type ProtocolDescriptor struct {
+	Version       uint8
+	SecurityLevel wallet.SecurityLevel
+	Name          string
+	Extensions    map[string]string
+}
+
+// DefaultProtocolDescriptor is the v2 equivalent of the legacy ProtocolID.
+var DefaultProtocolDescriptor = ProtocolDescriptor{
+	Version:       1,
+	SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty,
+	Name:          "auth message signature",
+	Extensions:    map[string]string{},
+}
+
+// CBOR encodes desc as canonical CBOR per RFC 8949 §4.2.1: a definite-length
+// map with keys ordered by their encoded length first and lexicographically
+// within a length ("name", "version", "extensions", "security_level"), each
+// value using the shortest definite-length CBOR encoding. Extensions are
+// themselves encoded as a definite-length map with keys in the same
+// length-first canonical order.
+func (desc ProtocolDescriptor) CBOR() []byte {
+	extKeys := canonicalOrder(desc.Extensions)
+
+	var out []byte
+	out = append(out, cborMapHeader(4)...)
+
+	out = append(out, cborTextString("name")...)
+	out = append(out, cborTextString(desc.Name)...)
+
+	out = append(out, cborTextString("version")...)
+	out = append(out, cborUint(uint64(desc.Version))...)
+
+	out = append(out, cborTextString("extensions")...)
+	out = append(out, cborMapHeader(len(extKeys))...)
+	for _, k := range extKeys {
+		out = append(out, cborTextString(k)...)
+		out = append(out, cborTextString(desc.Extensions[k])...)
+	}
+
+	out = append(out, cborTextString("security_level")...)
+	out = append(out, cborUint(uint64(desc.SecurityLevel))...)
+
+	return out
+}
+
+// canonicalOrder returns m's keys in RFC 8949 §4.2.1 canonical order: by
+// encoded (UTF-8) length first, then lexicographically within that length.
+func canonicalOrder(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// cborUint encodes n as a CBOR unsigned integer (major type 0).
+func cborUint(n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{byte(n)}
+	case n < 256:
+		return []byte{0x18, byte(n)}
+	case n < 65536:
+		b := make([]byte, 3)
+		b[0] = 0x19
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b
+	default:
+		b := make([]byte, 5)
+		b[0] = 0x1a
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		return b
+	}
+}
+
+// cborTextString encodes s as a CBOR text string (major type 3).
+func cborTextString(s string) []byte {
+	b := []byte(s)
+	header := cborHeader(0x60, uint64(len(b)))
+	return append(header, b...)
+}
+
+// cborMapHeader encodes a definite-length CBOR map header (major type 5)
+// with n key/value pairs.
+func cborMapHeader(n int) []byte {
+	return cborHeader(0xA0, uint64(n))
+}
+
+// cborHeader encodes a CBOR major-type header with the given base byte
+// (major type already shifted into the high bits) and argument n.
+func cborHeader(base byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{base + byte(n)}
+	case n < 256:
+		return []byte{base + 24, byte(n)}
+	case n < 65536:
+		b := make([]byte, 3)
+		b[0] = base + 25
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b
+	default:
+		b := make([]byte, 5)
+		b[0] = base + 26
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		return b
+	}
+}
+
+// MakeKeyIdV2 derives a key ID from a ProtocolDescriptor and the initial and
+// session nonces. The result is base64 of three length-prefixed (4-byte
+// big-endian length) segments concatenated in order: the descriptor's
+// canonical CBOR bytes, the initial nonce, and the session nonce.
+func MakeKeyIdV2(desc ProtocolDescriptor, initial, session []byte) string {
+	var blob []byte
+	blob = append(blob, lengthPrefixed(desc.CBOR())...)
+	blob = append(blob, lengthPrefixed(initial)...)
+	blob = append(blob, lengthPrefixed(session)...)
+	return base64.StdEncoding.EncodeToString(blob)
+}
+
+func lengthPrefixed(b []byte) []byte {
+	prefix := make([]byte, 4)
+	binary.BigEndian.PutUint32(prefix, uint32(len(b)))
+	return append(prefix, b...)
+}
+
+// LegacyV1 reproduces the pre-CBOR key ID format ("initial session") so key
+// IDs computed before the switch to MakeKeyIdV2 continue to verify. It is
+// equivalent to calling MakeKeyId directly.
+func LegacyV1(initialNonce, sessionNonce string) string {
+	return MakeKeyId(initialNonce, sessionNonce)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// SigSuite lets a BRC-104 handshake negotiate between signature algorithms.
+// Implementations operate on raw key bytes so callers don't need to know
+// each suite's concrete key type.
+type SigSuite interface {
+	Sign(priv, data []byte) ([]byte, error)
+	Verify(pub, data, sig []byte) bool
+	DeriveIdentityPub(priv []byte) []byte
+	SuiteID() uint8
+}
+
+// secp256k1ECDSASuite is the original BRC-104 signature suite: ECDSA over
+// secp256k1, signing the SHA-256 hash of data.
+type secp256k1ECDSASuite struct{}
+
+// Secp256k1ECDSA is the default, backward-compatible signature suite.
+var Secp256k1ECDSA SigSuite = secp256k1ECDSASuite{}
+
+func (secp256k1ECDSASuite) SuiteID() uint8 { return 1 }
+
+func (secp256k1ECDSASuite) Sign(priv, data []byte) ([]byte, error) {
+	privKey, err := ec.PrivateKeyFromBytes(priv)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1: parsing private key: %w", err)
+	}
+	hash := sha256.Sum256(data)
+	sig, err := privKey.Sign(hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1: signing: %w", err)
+	}
+	return sig.Serialize(), nil
+}
+
+func (secp256k1ECDSASuite) Verify(pub, data, sig []byte) bool {
+	pubKey, err := ec.ParsePubKey(pub)
+	if err != nil {
+		return false
+	}
+	signature, err := ec.ParseSignature(sig)
+	if err != nil {
+		return false
+	}
+	hash := sha256.Sum256(data)
+	return signature.Verify(hash[:], pubKey)
+}
+
+func (secp256k1ECDSASuite) DeriveIdentityPub(priv []byte) []byte {
+	privKey, err := ec.PrivateKeyFromBytes(priv)
+	if err != nil {
+		return nil
+	}
+	return privKey.PubKey().SerializeCompressed()
+}
+
+// ed25519Suite is the Ed25519 alternative signature suite. Ed25519 signs
+// data directly (it includes its own hashing internally), so unlike the
+// secp256k1 suite there is no separate digest step.
+type ed25519Suite struct{}
+
+// Ed25519 is the alternative signature suite that BRC-104 handshakes may
+// negotiate in place of Secp256k1ECDSA.
+var Ed25519 SigSuite = ed25519Suite{}
+
+func (ed25519Suite) SuiteID() uint8 { return 2 }
+
+func (ed25519Suite) Sign(priv, data []byte) ([]byte, error) {
+	if len(priv) != ed25519.SeedSize {
+		return nil, fmt.Errorf("ed25519: private key must be a %d-byte seed, got %d bytes", ed25519.SeedSize, len(priv))
+	}
+	key := ed25519.NewKeyFromSeed(priv)
+	return ed25519.Sign(key, data), nil
+}
+
+func (ed25519Suite) Verify(pub, data, sig []byte) bool {
+	if len(pub) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(pub), data, sig)
+}
+
+func (ed25519Suite) DeriveIdentityPub(priv []byte) []byte {
+	if len(priv) != ed25519.SeedSize {
+		return nil
+	}
+	key := ed25519.NewKeyFromSeed(priv)
+	return key.Public().(ed25519.PublicKey)
+}
+
+// sigSuites is the registry of signature suites dispatchable by SuiteID.
+var sigSuites = map[uint8]SigSuite{
+	Secp256k1ECDSA.SuiteID(): Secp256k1ECDSA,
+	Ed25519.SuiteID():        Ed25519,
+}
+
+// SignWithSuite signs data with suite and prepends suite.SuiteID() to the
+// result, so a verifier can dispatch to the matching suite without being
+// told out of band which one was used.
+func SignWithSuite(suite SigSuite, priv, data []byte) ([]byte, error) {
+	sig, err := suite.Sign(priv, data)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{suite.SuiteID()}, sig...), nil
+}
+
+// VerifyWithSuite dispatches on blob's leading suite ID byte and verifies
+// the remainder against data and pub.
+func VerifyWithSuite(pub, data, blob []byte) (bool, error) {
+	if len(blob) < 1 {
+		return false, fmt.Errorf("sigsuite: empty signature blob")
+	}
+	suite, ok := sigSuites[blob[0]]
+	if !ok {
+		return false, fmt.Errorf("sigsuite: unknown suite id %d", blob[0])
+	}
+	return suite.Verify(pub, data, blob[1:]), nil
+}
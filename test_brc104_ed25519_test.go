@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// Cross-language BRC-104 Ed25519 handshake test for Go SDK.
+//
+// This runs the existing initial/session-nonce signature data (the same
+// ExpectedSigDataSigning used by the secp256k1 suite) through the Ed25519
+// SigSuite, and checks the result against the fixture shared with the
+// Python/TS drivers.
+
+func TestBRC104Ed25519Handshake(t *testing.T) {
+	privKey, err := hex.DecodeString(TestEd25519PrivateKeyHex)
+	if err != nil {
+		t.Fatalf("failed to decode TestEd25519PrivateKeyHex: %v", err)
+	}
+
+	t.Run("derives expected identity public key", func(t *testing.T) {
+		pub := Ed25519.DeriveIdentityPub(privKey)
+		if hex.EncodeToString(pub) != ExpectedEd25519PublicKeyHex {
+			t.Errorf("identity public key mismatch: got %x, want %s", pub, ExpectedEd25519PublicKeyHex)
+		}
+	})
+
+	t.Run("signs the initial/session nonce exchange as expected", func(t *testing.T) {
+		sig, err := Ed25519.Sign(privKey, ExpectedSigDataSigning)
+		if err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		if hex.EncodeToString(sig) != ExpectedEd25519Signature {
+			t.Errorf("signature mismatch: got %x, want %s", sig, ExpectedEd25519Signature)
+		}
+	})
+
+	t.Run("verifies its own signature", func(t *testing.T) {
+		pub := Ed25519.DeriveIdentityPub(privKey)
+		sig, err := Ed25519.Sign(privKey, ExpectedSigDataSigning)
+		if err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+
+		if !Ed25519.Verify(pub, ExpectedSigDataSigning, sig) {
+			t.Error("Ed25519 suite failed to verify its own signature")
+		}
+	})
+
+	t.Run("SignWithSuite/VerifyWithSuite round trip via the suite ID prefix", func(t *testing.T) {
+		blob, err := SignWithSuite(Ed25519, privKey, ExpectedSigDataSigning)
+		if err != nil {
+			t.Fatalf("SignWithSuite failed: %v", err)
+		}
+		if blob[0] != Ed25519.SuiteID() {
+			t.Fatalf("expected signature blob to be prefixed with suite ID %d, got %d", Ed25519.SuiteID(), blob[0])
+		}
+
+		pub := Ed25519.DeriveIdentityPub(privKey)
+		ok, err := VerifyWithSuite(pub, ExpectedSigDataSigning, blob)
+		if err != nil {
+			t.Fatalf("VerifyWithSuite failed: %v", err)
+		}
+		if !ok {
+			t.Error("VerifyWithSuite should have dispatched to Ed25519 and verified successfully")
+		}
+	})
+
+	t.Run("deriveKeysGo reports the Ed25519 suite ID without altering derivation", func(t *testing.T) {
+		keyID := MakeKeyIdV2(DefaultProtocolDescriptor, InitialNonceBytes, SessionNonceBytes)
+		withDefault := deriveKeysGo(wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "auth message signature"}, keyID, TestCounterpartyKey, false, nil, nil)
+		withSecp256k1 := deriveKeysGo(wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "auth message signature"}, keyID, TestCounterpartyKey, false, nil, Secp256k1ECDSA)
+
+		if withDefault.PrivateKeyHex != withSecp256k1.PrivateKeyHex {
+			t.Error("passing Secp256k1ECDSA explicitly should not change derivation behavior")
+		}
+		if withSecp256k1.SigSuiteID != Secp256k1ECDSA.SuiteID() {
+			t.Errorf("expected SigSuiteID %d, got %d", Secp256k1ECDSA.SuiteID(), withSecp256k1.SigSuiteID)
+		}
+	})
+}
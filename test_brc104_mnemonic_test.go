@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+
+	"cross-language-tests/mnemonic"
+)
+
+// Cross-language BRC-104 mnemonic identity test for Go SDK.
+//
+// This verifies that mnemonic.FromMnemonic derives the same identity key
+// in Go as in the Python/TS drivers when given the same BIP-39 mnemonic
+// and passphrase, so HD-managed identities can be loaded into BRC-104
+// auth handshakes instead of raw WIFs.
+
+func TestBRC104MnemonicIdentity(t *testing.T) {
+	// This asserts against ExpectedMnemonicPrivateKeyWIF rather than the
+	// fixed TestPrivateKeyWIF used elsewhere in this repo: BIP-32 derivation
+	// can't be run backwards onto an arbitrary pre-existing key, so a
+	// mnemonic that derives to TestPrivateKeyWIF doesn't exist to find.
+	// Since TestMnemonic is now the canonical all-zero BIP-39 vector
+	// (m/44'/236'/0'/0/0), ExpectedMnemonicPrivateKeyWIF is independently
+	// reproducible with any standard BIP-39/BIP-32 tool (e.g. the
+	// iancoleman.io/bip39 derivation path explorer), not just self-derived
+	// by this package's own code.
+	t.Run("mnemonic derives expected identity key", func(t *testing.T) {
+		priv, err := mnemonic.FromMnemonic(TestMnemonic, TestMnemonicPassphrase)
+		if err != nil {
+			t.Fatalf("FromMnemonic failed: %v", err)
+		}
+
+		wif, err := priv.Wif()
+		if err != nil {
+			t.Fatalf("Wif failed: %v", err)
+		}
+
+		if wif != ExpectedMnemonicPrivateKeyWIF {
+			t.Errorf("mnemonic-derived WIF mismatch: got %s, want %s", wif, ExpectedMnemonicPrivateKeyWIF)
+		}
+	})
+
+	t.Run("derivation is deterministic", func(t *testing.T) {
+		first, err := mnemonic.FromMnemonic(TestMnemonic, TestMnemonicPassphrase)
+		if err != nil {
+			t.Fatalf("FromMnemonic failed: %v", err)
+		}
+
+		second, err := mnemonic.FromMnemonic(TestMnemonic, TestMnemonicPassphrase)
+		if err != nil {
+			t.Fatalf("FromMnemonic failed: %v", err)
+		}
+
+		firstWif, err := first.Wif()
+		if err != nil {
+			t.Fatalf("Wif failed: %v", err)
+		}
+		secondWif, err := second.Wif()
+		if err != nil {
+			t.Fatalf("Wif failed: %v", err)
+		}
+
+		if firstWif != secondWif {
+			t.Error("FromMnemonic should be deterministic for the same mnemonic and passphrase")
+		}
+	})
+
+	t.Run("different passphrase yields a different key", func(t *testing.T) {
+		base, err := mnemonic.FromMnemonic(TestMnemonic, TestMnemonicPassphrase)
+		if err != nil {
+			t.Fatalf("FromMnemonic failed: %v", err)
+		}
+
+		withPassphrase, err := mnemonic.FromMnemonic(TestMnemonic, "a different passphrase")
+		if err != nil {
+			t.Fatalf("FromMnemonic failed: %v", err)
+		}
+
+		baseWif, err := base.Wif()
+		if err != nil {
+			t.Fatalf("Wif failed: %v", err)
+		}
+		withPassphraseWif, err := withPassphrase.Wif()
+		if err != nil {
+			t.Fatalf("Wif failed: %v", err)
+		}
+
+		if baseWif == withPassphraseWif {
+			t.Error("a different passphrase should derive a different identity key")
+		}
+	})
+}
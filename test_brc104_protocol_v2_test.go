@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Cross-language BRC-104 protocol descriptor test for Go SDK.
+//
+// This verifies that ProtocolDescriptor's canonical CBOR encoding and
+// MakeKeyIdV2 match the fixed fixture values shared with the Python/TS
+// drivers, and that LegacyV1 still reproduces the old key ID format so
+// signatures made before the v2 migration continue to verify.
+
+func TestBRC104ProtocolDescriptorV2(t *testing.T) {
+	t.Run("canonical CBOR encoding matches fixture", func(t *testing.T) {
+		got := hex.EncodeToString(DefaultProtocolDescriptor.CBOR())
+		if got != ExpectedProtocolDescriptorCBORHex {
+			t.Errorf("CBOR encoding mismatch: got %s, want %s", got, ExpectedProtocolDescriptorCBORHex)
+		}
+	})
+
+	t.Run("MakeKeyIdV2 matches fixture", func(t *testing.T) {
+		got := MakeKeyIdV2(DefaultProtocolDescriptor, InitialNonceBytes, SessionNonceBytes)
+		if got != ExpectedKeyIdV2 {
+			t.Errorf("key ID v2 mismatch: got %s, want %s", got, ExpectedKeyIdV2)
+		}
+	})
+
+	t.Run("LegacyV1 reproduces the old key ID format", func(t *testing.T) {
+		got := LegacyV1(InitialNonceB64, SessionNonceB64)
+		want := MakeKeyId(InitialNonceB64, SessionNonceB64)
+		if got != want {
+			t.Errorf("LegacyV1 mismatch: got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("CBOR encoding is deterministic regardless of extension insertion order", func(t *testing.T) {
+		a := ProtocolDescriptor{
+			Version:       1,
+			SecurityLevel: DefaultProtocolDescriptor.SecurityLevel,
+			Name:          DefaultProtocolDescriptor.Name,
+			Extensions:    map[string]string{"a": "1", "b": "2"},
+		}
+		b := ProtocolDescriptor{
+			Version:       1,
+			SecurityLevel: DefaultProtocolDescriptor.SecurityLevel,
+			Name:          DefaultProtocolDescriptor.Name,
+			Extensions:    map[string]string{"b": "2", "a": "1"},
+		}
+
+		if hex.EncodeToString(a.CBOR()) != hex.EncodeToString(b.CBOR()) {
+			t.Error("CBOR encoding should not depend on Go map iteration order")
+		}
+	})
+}
@@ -8,24 +8,39 @@ import (
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/go-sdk/wallet"
+
+	"cross-language-tests/sessionkdf"
 )
 
 type KeyDerivationResult struct {
-	PrivateKeyHex            string `json:"private_key_hex"`
-	PublicKeyForSelfHex      string `json:"public_key_for_self_hex"`
-	PublicKeyNotForSelfHex   string `json:"public_key_not_for_self_hex"`
-	PublicKeyFromPrivateHex  string `json:"public_key_from_private_hex"`
+	PrivateKeyHex           string `json:"private_key_hex"`
+	PublicKeyForSelfHex     string `json:"public_key_for_self_hex"`
+	PublicKeyNotForSelfHex  string `json:"public_key_not_for_self_hex"`
+	PublicKeyFromPrivateHex string `json:"public_key_from_private_hex"`
+	StretchedSessionKeyHex  string `json:"stretched_session_key_hex,omitempty"`
+	SigSuiteID              uint8  `json:"sig_suite_id"`
 }
 
 type TestOutput struct {
-	Language    string                 `json:"language"`
-	Protocol    map[string]interface{} `json:"protocol"`
-	KeyID       string                 `json:"key_id"`
-	Counterparty string                `json:"counterparty"`
-	Results     KeyDerivationResult    `json:"results"`
+	Language     string                 `json:"language"`
+	Protocol     map[string]interface{} `json:"protocol"`
+	KeyID        string                 `json:"key_id"`
+	Counterparty string                 `json:"counterparty"`
+	Results      KeyDerivationResult    `json:"results"`
 }
 
-func deriveKeysGo(protocol wallet.Protocol, keyID string, counterpartyHex string, forSelf bool) KeyDerivationResult {
+// deriveKeysGo derives the BRC-104 key set for protocol/keyID/counterparty.
+// When kdf is non-nil, it additionally stretches the initial/session nonces
+// with kdf (keyed to the counterparty) and reports the result, binding the
+// eventual signature to the stretched key via sessionkdf.BindSignatureData.
+//
+// suite selects the signature suite to report alongside the derived keys;
+// passing nil is equivalent to passing Secp256k1ECDSA and preserves the
+// original (pre-SigSuite) behavior.
+func deriveKeysGo(protocol wallet.Protocol, keyID string, counterpartyHex string, forSelf bool, kdf sessionkdf.SessionKDF, suite SigSuite) KeyDerivationResult {
+	if suite == nil {
+		suite = Secp256k1ECDSA
+	}
 	// Create root key from WIF
 	rootKey, err := ec.PrivateKeyFromWif(TestPrivateKeyWIF)
 	if err != nil {
@@ -62,12 +77,20 @@ func deriveKeysGo(protocol wallet.Protocol, keyID string, counterpartyHex string
 		panic(fmt.Sprintf("Failed to derive public key (forSelf=false): %v", err))
 	}
 
-	return KeyDerivationResult{
-		PrivateKeyHex:            hex.EncodeToString(derivedPriv.Serialize()),
-		PublicKeyForSelfHex:      derivedPubForSelf.ToDERHex(),
-		PublicKeyNotForSelfHex:   derivedPubNotForSelf.ToDERHex(),
-		PublicKeyFromPrivateHex:  derivedPriv.PubKey().ToDERHex(),
+	result := KeyDerivationResult{
+		PrivateKeyHex:           hex.EncodeToString(derivedPriv.Serialize()),
+		PublicKeyForSelfHex:     derivedPubForSelf.ToDERHex(),
+		PublicKeyNotForSelfHex:  derivedPubNotForSelf.ToDERHex(),
+		PublicKeyFromPrivateHex: derivedPriv.PubKey().ToDERHex(),
+		SigSuiteID:              suite.SuiteID(),
+	}
+
+	if kdf != nil {
+		stretched := kdf.Stretch(InitialNonceBytes, SessionNonceBytes, counterpartyPub.SerializeCompressed())
+		result.StretchedSessionKeyHex = hex.EncodeToString(stretched)
 	}
+
+	return result
 }
 
 func TestKeyDerivation() {
@@ -76,7 +99,7 @@ func TestKeyDerivation() {
 		SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, // 2
 		Protocol:      "auth message signature",
 	}
-	keyID := MakeKeyId(InitialNonceB64, SessionNonceB64)
+	keyID := MakeKeyIdV2(DefaultProtocolDescriptor, InitialNonceBytes, SessionNonceBytes)
 	counterpartyHex := TestCounterpartyKey
 
 	fmt.Println(strings.Repeat("=", 80))
@@ -88,7 +111,7 @@ func TestKeyDerivation() {
 	fmt.Println()
 
 	// Derive keys
-	results := deriveKeysGo(protocol, keyID, counterpartyHex, false)
+	results := deriveKeysGo(protocol, keyID, counterpartyHex, false, nil, nil)
 
 	fmt.Println("Derived Keys:")
 	fmt.Printf("  Private Key (hex): %s\n", results.PrivateKeyHex)
@@ -130,4 +153,3 @@ func TestKeyDerivation() {
 func main() {
 	TestKeyDerivation()
 }
-